@@ -0,0 +1,46 @@
+package alice
+
+import "github.com/valyala/fasthttp"
+
+type abortKeyType struct{}
+
+// abortKey is the ctx.UserValue key used to flag an aborted chain. It is an
+// unexported type so it cannot collide with keys set by other packages.
+var abortKey = abortKeyType{}
+
+// Abort flags ctx so that any remaining middleware and the final handler in
+// a chain built with Chain.ThenWithAbort are skipped. It does not stop the
+// currently running handler; callers should return immediately after
+// calling Abort.
+func Abort(ctx *fasthttp.RequestCtx) {
+	ctx.SetUserValue(abortKey, true)
+}
+
+// IsAborted reports whether Abort has been called for ctx.
+func IsAborted(ctx *fasthttp.RequestCtx) bool {
+	aborted, _ := ctx.UserValue(abortKey).(bool)
+	return aborted
+}
+
+// ThenWithAbort chains the middleware like Then, but checks IsAborted
+// between every constructed layer, skipping the remaining middleware and the
+// final handler h once Abort has been called. This lets middleware such as
+// auth or rate-limiting stop the chain without relying on ad-hoc
+// conventions such as a sentinel status code.
+func (c Chain) ThenWithAbort(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+	h = guardAbort(h)
+	for i := len(c.constructors) - 1; i >= 0; i-- {
+		h = guardAbort(c.constructors[i](h))
+	}
+	return h
+}
+
+// guardAbort wraps h so that it is skipped once IsAborted(ctx) is true.
+func guardAbort(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if IsAborted(ctx) {
+			return
+		}
+		h(ctx)
+	}
+}
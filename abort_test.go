@@ -0,0 +1,51 @@
+package alice
+
+import (
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func abortingMiddleware(tag string) Constructor {
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			ctx.Write([]byte(tag))
+			Abort(ctx)
+			h(ctx)
+		}
+	}
+}
+
+func TestThenWithAbortStopsRemainingMiddleware(t *testing.T) {
+	t1 := tagMiddleware("t1\n")
+	t2 := abortingMiddleware("t2\n")
+	t3 := tagMiddleware("t3\n")
+
+	chained := New(t1, t2, t3).ThenWithAbort(testApp)
+	ctx := newGetCtx(t)
+
+	chained(ctx)
+	if string(ctx.Response.Body()) != "t1\nt2\n" {
+		t.Errorf("got %q, want %q", ctx.Response.Body(), "t1\nt2\n")
+	}
+}
+
+func TestThenWithAbortRunsEverythingWhenNotAborted(t *testing.T) {
+	t1 := tagMiddleware("t1\n")
+	t2 := tagMiddleware("t2\n")
+
+	chained := New(t1, t2).ThenWithAbort(testApp)
+	ctx := newGetCtx(t)
+
+	chained(ctx)
+	if string(ctx.Response.Body()) != "t1\nt2\napp\n" {
+		t.Errorf("got %q, want %q", ctx.Response.Body(), "t1\nt2\napp\n")
+	}
+}
+
+func TestIsAbortedDefaultsToFalse(t *testing.T) {
+	ctx := newGetCtx(t)
+	if IsAborted(ctx) {
+		t.Error("IsAborted should default to false")
+	}
+}
@@ -0,0 +1,82 @@
+// Package alice implements a middleware chaining solution for fasthttp.
+package alice
+
+import "github.com/valyala/fasthttp"
+
+// A constructor for a piece of middleware.
+// fasthttp middleware is just a function that wraps a fasthttp.RequestHandler
+// with additional functionality. Use Chain to combine constructors into a
+// full chain.
+type Constructor func(fasthttp.RequestHandler) fasthttp.RequestHandler
+
+// Chain acts as a list of fasthttp.RequestHandler constructors.
+// Chain is effectively immutable:
+// once created, it will always hold
+// the same set of constructors in the same order.
+type Chain struct {
+	constructors []Constructor
+	// names holds the registry names resolved by Use, in the order they
+	// were appended. Constructors added via New, Append or Extend have no
+	// entry here; see Names.
+	names []string
+}
+
+// New creates a new chain,
+// memorizing the given list of middleware constructors.
+// New serves no other function,
+// constructors are only called upon a call to Then().
+func New(constructors ...Constructor) Chain {
+	return Chain{constructors: append(([]Constructor)(nil), constructors...)}
+}
+
+// Then chains the middleware and returns the final fasthttp.RequestHandler.
+//
+//	New(m1, m2, m3).Then(h)
+//
+// is equivalent to:
+//
+//	m1(m2(m3(h)))
+//
+// When the request comes in, it will be passed to m1, then m2, then m3
+// and finally, the given handler
+// (assuming every middleware calls the following one).
+//
+// A chain can be safely reused by calling Then() several times.
+//
+//	stdStack := alice.New(ratelimitHandler, csrfHandler)
+//	indexPipe = stdStack.Then(indexHandler)
+//	authPipe = stdStack.Then(authHandler)
+//
+// Similarly, a chain is safe for concurrent use by multiple goroutines.
+func (c Chain) Then(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+	for i := len(c.constructors) - 1; i >= 0; i-- {
+		h = c.constructors[i](h)
+	}
+	return h
+}
+
+// Append extends a chain, adding the specified constructors
+// as the last ones in the request flow.
+//
+//	c := alice.New(m1, m2)
+//	c2 := c.Append(m3, m4)
+//	// requests in c go m1 -> m2
+//	// requests in c2 go m1 -> m2 -> m3 -> m4
+func (c Chain) Append(constructors ...Constructor) Chain {
+	newCons := make([]Constructor, 0, len(c.constructors)+len(constructors))
+	newCons = append(newCons, c.constructors...)
+	newCons = append(newCons, constructors...)
+	return Chain{constructors: newCons, names: c.names}
+}
+
+// Extend extends a chain by adding the specified chain as the last one
+// in the request flow.
+//
+//	c := alice.New(m1, m2)
+//	c2 := alice.New(m3, m4)
+//	c3 := c.Extend(c2)
+//	// requests in c go m1 -> m2
+//	// requests in c3 go m1 -> m2 -> m3 -> m4
+func (c Chain) Extend(chain Chain) Chain {
+	return c.Append(chain.constructors...)
+}
@@ -77,7 +77,7 @@ func TestThenFuncConstructsHandlerFunc(t *testing.T) {
 func newGetCtx(t *testing.T) *fasthttp.RequestCtx {
 	var ctx fasthttp.RequestCtx
 
-	s := "GET / HTTP/1.1\nHost: aaa.com\n\n"
+	s := "GET / HTTP/1.1\r\nHost: aaa.com\r\n\r\n"
 	br := bufio.NewReader(bytes.NewBufferString(s))
 	if err := ctx.Request.Read(br); err != nil {
 		t.Fatalf("cannot read request: %s", err)
@@ -0,0 +1,44 @@
+package alice
+
+import "github.com/valyala/fasthttp"
+
+// Predicate reports whether a conditional middleware group added with
+// Chain.When or Chain.Unless should run for the given request.
+type Predicate func(ctx *fasthttp.RequestCtx) bool
+
+// When appends a group of constructors that only run when pred returns true
+// for the live request. The predicate is evaluated at request time, not at
+// chain-build time, so it can inspect the request's path, method or headers.
+// When pred returns false, the group is bypassed entirely and the chain
+// falls through to whatever comes after it.
+//
+//	c := alice.New(logging).When(isAPIRoute, cors, auth).Then(h)
+func (c Chain) When(pred Predicate, cs ...Constructor) Chain {
+	return c.Append(conditional(pred, cs...))
+}
+
+// Unless appends a group of constructors that run for every request except
+// those for which pred returns true. It is the inverse of When.
+//
+//	c := alice.New(logging).Unless(isHealthCheck, auth).Then(h)
+func (c Chain) Unless(pred Predicate, cs ...Constructor) Chain {
+	return c.Append(conditional(func(ctx *fasthttp.RequestCtx) bool {
+		return !pred(ctx)
+	}, cs...))
+}
+
+// conditional builds a single Constructor that, at request time, either runs
+// the constructor stack built from cs or bypasses straight to h.
+func conditional(pred Predicate, cs ...Constructor) Constructor {
+	inner := New(cs...)
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		wrapped := inner.Then(h)
+		return func(ctx *fasthttp.RequestCtx) {
+			if pred(ctx) {
+				wrapped(ctx)
+				return
+			}
+			h(ctx)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package alice
+
+import (
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestWhenRunsGroupWhenPredicateTrue(t *testing.T) {
+	chained := New(tagMiddleware("t1\n")).
+		When(func(ctx *fasthttp.RequestCtx) bool { return true }, tagMiddleware("t2\n")).
+		Then(testApp)
+
+	ctx := newGetCtx(t)
+	chained(ctx)
+
+	if string(ctx.Response.Body()) != "t1\nt2\napp\n" {
+		t.Errorf("got %q, want %q", ctx.Response.Body(), "t1\nt2\napp\n")
+	}
+}
+
+func TestWhenBypassesGroupWhenPredicateFalse(t *testing.T) {
+	chained := New(tagMiddleware("t1\n")).
+		When(func(ctx *fasthttp.RequestCtx) bool { return false }, tagMiddleware("t2\n")).
+		Then(testApp)
+
+	ctx := newGetCtx(t)
+	chained(ctx)
+
+	if string(ctx.Response.Body()) != "t1\napp\n" {
+		t.Errorf("got %q, want %q", ctx.Response.Body(), "t1\napp\n")
+	}
+}
+
+func TestUnlessIsTheInverseOfWhen(t *testing.T) {
+	chained := New().
+		Unless(func(ctx *fasthttp.RequestCtx) bool { return true }, tagMiddleware("t1\n")).
+		Then(testApp)
+
+	ctx := newGetCtx(t)
+	chained(ctx)
+
+	if string(ctx.Response.Body()) != "app\n" {
+		t.Errorf("got %q, want %q", ctx.Response.Body(), "app\n")
+	}
+}
@@ -0,0 +1,37 @@
+// Package middleware provides ready-made alice.Constructor implementations
+// for concerns every fasthttp service ends up needing: access logging,
+// panic recovery and request-ID propagation.
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"github.com/zc310/alice"
+)
+
+// AccessLog returns a Constructor that writes one line per request to w,
+// recording method, path, status code, response size, latency and the
+// remote address. If w is nil, AccessLog writes to os.Stdout.
+func AccessLog(w io.Writer) alice.Constructor {
+	if w == nil {
+		w = os.Stdout
+	}
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			start := time.Now()
+			h(ctx)
+			fmt.Fprintf(w, "%s %s %d %d %s %s\n",
+				ctx.Method(),
+				ctx.Path(),
+				ctx.Response.StatusCode(),
+				len(ctx.Response.Body()),
+				time.Since(start),
+				ctx.RemoteAddr(),
+			)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func newGetCtx(t *testing.T) *fasthttp.RequestCtx {
+	var ctx fasthttp.RequestCtx
+
+	s := "GET /widgets HTTP/1.1\r\nHost: aaa.com\r\n\r\n"
+	br := bufio.NewReader(bytes.NewBufferString(s))
+	if err := ctx.Request.Read(br); err != nil {
+		t.Fatalf("cannot read request: %s", err)
+	}
+	return &ctx
+}
+
+func TestAccessLogWritesARequestLine(t *testing.T) {
+	var buf bytes.Buffer
+	h := AccessLog(&buf)(func(ctx *fasthttp.RequestCtx) {
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		ctx.WriteString("ok")
+	})
+
+	h(newGetCtx(t))
+
+	line := buf.String()
+	if !strings.Contains(line, "GET") || !strings.Contains(line, "/widgets") || !strings.Contains(line, "200") {
+		t.Errorf("access log line missing expected fields: %q", line)
+	}
+}
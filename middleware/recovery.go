@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+
+	"github.com/valyala/fasthttp"
+	"github.com/zc310/alice"
+)
+
+// Recovery returns a Constructor that recovers from panics raised by
+// downstream middleware or the final handler, writes a 500 response and
+// logs the panic value with its stack trace to w. Without Recovery, a
+// panicking handler takes down the whole fasthttp worker goroutine. If w is
+// nil, Recovery writes to os.Stderr.
+func Recovery(w io.Writer) alice.Constructor {
+	if w == nil {
+		w = os.Stderr
+	}
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			defer func() {
+				if r := recover(); r != nil {
+					fmt.Fprintf(w, "panic: %v\n%s\n", r, debug.Stack())
+					ctx.Response.Reset()
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				}
+			}()
+			h(ctx)
+		}
+	}
+}
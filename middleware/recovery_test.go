@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestRecoveryConvertsPanicToInternalServerError(t *testing.T) {
+	var buf bytes.Buffer
+	h := Recovery(&buf)(func(ctx *fasthttp.RequestCtx) {
+		panic("boom")
+	})
+
+	ctx := newGetCtx(t)
+	h(ctx)
+
+	if ctx.Response.StatusCode() != fasthttp.StatusInternalServerError {
+		t.Errorf("got status %d, want %d", ctx.Response.StatusCode(), fasthttp.StatusInternalServerError)
+	}
+	if !strings.Contains(buf.String(), "boom") {
+		t.Errorf("recovery log missing panic value: %q", buf.String())
+	}
+}
+
+func TestRecoveryLetsNonPanickingRequestsThrough(t *testing.T) {
+	var buf bytes.Buffer
+	h := Recovery(&buf)(func(ctx *fasthttp.RequestCtx) {
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	})
+
+	ctx := newGetCtx(t)
+	h(ctx)
+
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Errorf("got status %d, want %d", ctx.Response.StatusCode(), fasthttp.StatusOK)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Recovery logged on a non-panicking request: %q", buf.String())
+	}
+}
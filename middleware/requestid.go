@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/valyala/fasthttp"
+	"github.com/zc310/alice"
+)
+
+// RequestIDHeader is the header RequestID reads an inbound request ID from,
+// and echoes the resolved request ID back on, for both request and
+// response.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the ctx.UserValue key RequestID stores the resolved
+// request ID under.
+type requestIDKey struct{}
+
+// RequestID returns a Constructor that ensures every request carries a
+// request ID: it reuses the inbound X-Request-ID header when present,
+// otherwise generates one, stores it in ctx.UserValue for downstream
+// middleware and handlers via RequestIDFromContext, and sets it on the
+// response.
+func RequestID() alice.Constructor {
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			id := string(ctx.Request.Header.Peek(RequestIDHeader))
+			if id == "" {
+				id = newRequestID()
+			}
+			ctx.SetUserValue(requestIDKey{}, id)
+			ctx.Response.Header.Set(RequestIDHeader, id)
+			h(ctx)
+		}
+	}
+}
+
+// RequestIDFromContext returns the request ID stored by RequestID, or the
+// empty string if RequestID was not used on this chain.
+func RequestIDFromContext(ctx *fasthttp.RequestCtx) string {
+	id, _ := ctx.UserValue(requestIDKey{}).(string)
+	return id
+}
+
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestRequestIDGeneratesOneWhenAbsent(t *testing.T) {
+	var seen string
+	h := RequestID()(func(ctx *fasthttp.RequestCtx) {
+		seen = RequestIDFromContext(ctx)
+	})
+
+	ctx := newGetCtx(t)
+	h(ctx)
+
+	if seen == "" {
+		t.Error("RequestID did not store a generated ID in ctx.UserValue")
+	}
+	if got := string(ctx.Response.Header.Peek(RequestIDHeader)); got != seen {
+		t.Errorf("response header %q, want %q", got, seen)
+	}
+}
+
+func TestRequestIDReusesInboundHeader(t *testing.T) {
+	var seen string
+	h := RequestID()(func(ctx *fasthttp.RequestCtx) {
+		seen = RequestIDFromContext(ctx)
+	})
+
+	ctx := newGetCtx(t)
+	ctx.Request.Header.Set(RequestIDHeader, "fixed-id")
+	h(ctx)
+
+	if seen != "fixed-id" {
+		t.Errorf("got %q, want %q", seen, "fixed-id")
+	}
+}
@@ -0,0 +1,62 @@
+package alice
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Constructor)
+)
+
+// Register makes a Constructor available under name for later use with
+// Chain.Use. It is intended to be called from an init function, in the
+// manner of database/sql.Register, so that config-driven middleware
+// pipelines can refer to constructors by name instead of by Go reference.
+// Register panics if c is nil or if name is already registered.
+func Register(name string, c Constructor) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if c == nil {
+		panic("alice: Register constructor is nil")
+	}
+	if _, dup := registry[name]; dup {
+		panic("alice: Register called twice for middleware " + name)
+	}
+	registry[name] = c
+}
+
+// Use resolves names against the constructors passed to Register and
+// appends them as the last ones in the request flow, in the order given.
+//
+//	alice.Register("cors", corsMiddleware)
+//	alice.Register("auth", authMiddleware)
+//	c := alice.New().Use("cors", "auth")
+//
+// Use panics if any name was never passed to Register.
+func (c Chain) Use(names ...string) Chain {
+	cons := make([]Constructor, len(names))
+
+	registryMu.RLock()
+	for i, name := range names {
+		constructor, ok := registry[name]
+		if !ok {
+			registryMu.RUnlock()
+			panic(fmt.Sprintf("alice: Use: unknown middleware %q", name))
+		}
+		cons[i] = constructor
+	}
+	registryMu.RUnlock()
+
+	newChain := c.Append(cons...)
+	newChain.names = append(append([]string(nil), c.names...), names...)
+	return newChain
+}
+
+// Names returns the registry names resolved by Use to build this chain, in
+// the order they were appended. Constructors added via New, Append or
+// Extend rather than Use have no entry here.
+func (c Chain) Names() []string {
+	return append([]string(nil), c.names...)
+}
@@ -0,0 +1,49 @@
+package alice
+
+import (
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestUseResolvesRegisteredConstructors(t *testing.T) {
+	Register("registry-test-t1", tagMiddleware("t1\n"))
+	Register("registry-test-t2", tagMiddleware("t2\n"))
+
+	chained := New().Use("registry-test-t1", "registry-test-t2").Then(testApp)
+	ctx := newGetCtx(t)
+	chained(ctx)
+
+	if string(ctx.Response.Body()) != "t1\nt2\napp\n" {
+		t.Errorf("got %q, want %q", ctx.Response.Body(), "t1\nt2\napp\n")
+	}
+}
+
+func TestUsePanicsOnUnknownName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Use did not panic on an unknown middleware name")
+		}
+	}()
+	New().Use("registry-test-does-not-exist")
+}
+
+func TestRegisterPanicsOnDuplicateName(t *testing.T) {
+	Register("registry-test-dup", tagMiddleware(""))
+	defer func() {
+		if recover() == nil {
+			t.Error("Register did not panic on a duplicate name")
+		}
+	}()
+	Register("registry-test-dup", tagMiddleware(""))
+}
+
+func TestNamesReflectsUseCalls(t *testing.T) {
+	Register("registry-test-names", func(h fasthttp.RequestHandler) fasthttp.RequestHandler { return h })
+
+	c := New(tagMiddleware("t1\n")).Use("registry-test-names")
+	names := c.Names()
+	if len(names) != 1 || names[0] != "registry-test-names" {
+		t.Errorf("got %v, want [registry-test-names]", names)
+	}
+}
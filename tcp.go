@@ -0,0 +1,121 @@
+package alice
+
+import "net"
+
+// TCPHandler is implemented by types that can serve a raw TCP connection,
+// analogous to fasthttp.RequestHandler but operating below the HTTP layer.
+type TCPHandler interface {
+	ServeTCP(conn net.Conn)
+}
+
+// TCPHandlerFunc adapts an ordinary function to a TCPHandler.
+type TCPHandlerFunc func(conn net.Conn)
+
+// ServeTCP calls f(conn).
+func (f TCPHandlerFunc) ServeTCP(conn net.Conn) {
+	f(conn)
+}
+
+// TCPConstructor is a constructor for a piece of TCP middleware. Unlike
+// Constructor, building a layer can fail: middleware such as TLS
+// termination, proxy-protocol parsing or rate-limit setup may need to
+// validate its configuration while the chain itself is being built, and
+// reports that failure here instead of deferring it to connection time.
+type TCPConstructor func(TCPHandler) (TCPHandler, error)
+
+// TCPChain acts as a list of TCPHandler constructors.
+// TCPChain is effectively immutable:
+// once created, it will always hold
+// the same set of constructors in the same order.
+type TCPChain struct {
+	constructors []TCPConstructor
+}
+
+// NewTCP creates a new TCP chain,
+// memorizing the given list of middleware constructors.
+// NewTCP serves no other function,
+// constructors are only called upon a call to Then().
+func NewTCP(constructors ...TCPConstructor) TCPChain {
+	return TCPChain{append(([]TCPConstructor)(nil), constructors...)}
+}
+
+// Then chains the middleware and returns the final TCPHandler.
+//
+//	NewTCP(m1, m2, m3).Then(h)
+//
+// is equivalent to:
+//
+//	m1(m2(m3(h)))
+//
+// Unlike Chain.Then, building a TCPChain can fail. Then calls each
+// constructor exactly once, in the order the constructors were declared,
+// and stops at the first one that returns a non-nil error, discarding any
+// handler built so far and never calling a constructor declared after it.
+//
+// To call constructors in declaration order while still nesting m1 as the
+// outermost handler, Then hands constructor i a deferredTCPHandler standing
+// in for constructor i+1's eventual result; every deferredTCPHandler is
+// resolved before Then returns. Each layer keeps calling its successor
+// through that stand-in, so serving a connection passes through one
+// deferredTCPHandler between each pair of adjacent layers.
+func (c TCPChain) Then(h TCPHandler) (TCPHandler, error) {
+	n := len(c.constructors)
+	deferred := make([]deferredTCPHandler, n)
+	built := make([]TCPHandler, n)
+
+	for i := 0; i < n; i++ {
+		downstream := h
+		if i+1 < n {
+			downstream = &deferred[i+1]
+		}
+
+		var err error
+		built[i], err = c.constructors[i](downstream)
+		if err != nil {
+			return nil, err
+		}
+		deferred[i].handler = built[i]
+	}
+
+	if n == 0 {
+		return h, nil
+	}
+	return built[0], nil
+}
+
+// deferredTCPHandler stands in for a TCPHandler that Then has not finished
+// building yet. Its handler field is set once the real handler has been
+// built, before Then returns.
+type deferredTCPHandler struct {
+	handler TCPHandler
+}
+
+func (d *deferredTCPHandler) ServeTCP(conn net.Conn) {
+	d.handler.ServeTCP(conn)
+}
+
+// Append extends a TCP chain, adding the specified constructors
+// as the last ones in the request flow.
+//
+//	c := alice.NewTCP(m1, m2)
+//	c2 := c.Append(m3, m4)
+//	// connections in c go m1 -> m2
+//	// connections in c2 go m1 -> m2 -> m3 -> m4
+func (c TCPChain) Append(constructors ...TCPConstructor) TCPChain {
+	newCons := make([]TCPConstructor, 0, len(c.constructors)+len(constructors))
+	newCons = append(newCons, c.constructors...)
+	newCons = append(newCons, constructors...)
+	return TCPChain{newCons}
+}
+
+// Extend extends a TCP chain by adding the specified chain as the last one
+// in the request flow.
+//
+//	c := alice.NewTCP(m1, m2)
+//	c2 := alice.NewTCP(m3, m4)
+//	c3 := c.Extend(c2)
+//	// connections in c go m1 -> m2
+//	// connections in c3 go m1 -> m2 -> m3 -> m4
+func (c TCPChain) Extend(chain TCPChain) TCPChain {
+	return c.Append(chain.constructors...)
+}
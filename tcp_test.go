@@ -0,0 +1,107 @@
+package alice
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+// A constructor for TCP middleware
+// that writes its own "tag" into the buffer and does nothing else.
+// Useful in checking if a chain is behaving in the right order.
+func tagTCPMiddleware(tag string, buf *[]string) TCPConstructor {
+	return func(h TCPHandler) (TCPHandler, error) {
+		return TCPHandlerFunc(func(conn net.Conn) {
+			*buf = append(*buf, tag)
+			h.ServeTCP(conn)
+		}), nil
+	}
+}
+
+func failingTCPMiddleware(err error) TCPConstructor {
+	return func(h TCPHandler) (TCPHandler, error) {
+		return nil, err
+	}
+}
+
+func TestTCPThenOrdersHandlersCorrectly(t *testing.T) {
+	var got []string
+	app := TCPHandlerFunc(func(conn net.Conn) {
+		got = append(got, "app")
+	})
+
+	chained, err := NewTCP(
+		tagTCPMiddleware("t1", &got),
+		tagTCPMiddleware("t2", &got),
+		tagTCPMiddleware("t3", &got),
+	).Then(app)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	chained.ServeTCP(server)
+
+	want := []string{"t1", "t2", "t3", "app"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestTCPThenShortCircuitsOnError(t *testing.T) {
+	wantErr := errors.New("tls: bad certificate")
+	var built bool
+
+	_, err := NewTCP(
+		failingTCPMiddleware(wantErr),
+		func(h TCPHandler) (TCPHandler, error) {
+			built = true
+			return h, nil
+		},
+	).Then(TCPHandlerFunc(func(conn net.Conn) {}))
+
+	if err != wantErr {
+		t.Errorf("Then returned error %v, want %v", err, wantErr)
+	}
+	if built {
+		t.Error("Then called a constructor after a prior one failed")
+	}
+}
+
+func TestTCPAppendAddsHandlersCorrectly(t *testing.T) {
+	var got []string
+	chain := NewTCP(tagTCPMiddleware("t1", &got), tagTCPMiddleware("t2", &got))
+	newChain := chain.Append(tagTCPMiddleware("t3", &got), tagTCPMiddleware("t4", &got))
+
+	if len(chain.constructors) != 2 {
+		t.Error("chain should have 2 constructors")
+	}
+	if len(newChain.constructors) != 4 {
+		t.Error("newChain should have 4 constructors")
+	}
+}
+
+func TestTCPExtendAddsHandlersCorrectly(t *testing.T) {
+	var got []string
+	chain1 := NewTCP(tagTCPMiddleware("t1", &got), tagTCPMiddleware("t2", &got))
+	chain2 := NewTCP(tagTCPMiddleware("t3", &got), tagTCPMiddleware("t4", &got))
+	newChain := chain1.Extend(chain2)
+
+	if len(chain1.constructors) != 2 {
+		t.Error("chain1 should contain 2 constructors")
+	}
+	if len(chain2.constructors) != 2 {
+		t.Error("chain2 should contain 2 constructors")
+	}
+	if len(newChain.constructors) != 4 {
+		t.Error("newChain should contain 4 constructors")
+	}
+}
@@ -0,0 +1,117 @@
+package alice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures Tracing.
+type Option func(*tracingConfig)
+
+type tracingConfig struct {
+	propagator propagation.TextMapPropagator
+}
+
+// WithPropagator overrides the propagator Tracing uses to extract and
+// inject W3C Trace Context. The default is propagation.TraceContext{}.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *tracingConfig) {
+		c.propagator = p
+	}
+}
+
+// spanCtxKey is the ctx.UserValue key Tracing stores the request's
+// context.Context under.
+type spanCtxKey struct{}
+
+// Tracing returns a Constructor that starts a span per request with tracer.
+// It extracts an incoming traceparent/tracestate via the configured
+// propagator (propagation.TraceContext by default), records HTTP
+// semantic-convention attributes, and injects the resulting trace context
+// back onto the response headers. Because fasthttp does not thread a
+// context.Context through requests, Tracing bridges one carrying the active
+// span into ctx.UserValue; downstream middleware and handlers retrieve it
+// with SpanContextFrom. The span is finished, and any panic recorded as an
+// error, after the inner handler returns.
+func Tracing(tracer trace.Tracer, opts ...Option) Constructor {
+	cfg := tracingConfig{propagator: propagation.TraceContext{}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			carrier := fasthttpCarrier{ctx}
+			parent := cfg.propagator.Extract(context.Background(), carrier)
+
+			spanCtx, span := tracer.Start(parent, string(ctx.Path()),
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPMethodKey.String(string(ctx.Method())),
+					semconv.HTTPRouteKey.String(string(ctx.Path())),
+					semconv.UserAgentOriginalKey.String(string(ctx.UserAgent())),
+				),
+			)
+			defer span.End()
+
+			cfg.propagator.Inject(spanCtx, carrier)
+			ctx.SetUserValue(spanCtxKey{}, spanCtx)
+
+			defer func() {
+				if r := recover(); r != nil {
+					span.RecordError(fmt.Errorf("panic: %v", r))
+					span.SetStatus(codes.Error, "panic")
+					panic(r)
+				}
+			}()
+
+			h(ctx)
+
+			status := ctx.Response.StatusCode()
+			span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+			if status >= fasthttp.StatusInternalServerError {
+				span.SetStatus(codes.Error, "")
+			}
+		}
+	}
+}
+
+// SpanContextFrom returns the context.Context carrying the active span
+// started by Tracing, or context.Background() if Tracing was not used on
+// this chain.
+func SpanContextFrom(ctx *fasthttp.RequestCtx) context.Context {
+	spanCtx, _ := ctx.UserValue(spanCtxKey{}).(context.Context)
+	if spanCtx == nil {
+		return context.Background()
+	}
+	return spanCtx
+}
+
+// fasthttpCarrier adapts a fasthttp.RequestCtx to propagation.TextMapCarrier
+// so trace context headers can be extracted from the request and injected
+// into the response.
+type fasthttpCarrier struct {
+	ctx *fasthttp.RequestCtx
+}
+
+func (c fasthttpCarrier) Get(key string) string {
+	return string(c.ctx.Request.Header.Peek(key))
+}
+
+func (c fasthttpCarrier) Set(key, value string) {
+	c.ctx.Response.Header.Set(key, value)
+}
+
+func (c fasthttpCarrier) Keys() []string {
+	keys := make([]string, 0)
+	c.ctx.Request.Header.VisitAll(func(k, v []byte) {
+		keys = append(keys, string(k))
+	})
+	return keys
+}
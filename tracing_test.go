@@ -0,0 +1,167 @@
+package alice
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newRecordingTracer returns a real SDK tracer backed by an in-memory
+// exporter, so assertions can inspect the spans Tracing actually produces
+// instead of relying on a no-op implementation.
+func newRecordingTracer(t *testing.T) (trace.Tracer, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+	return tp.Tracer("alice_test"), exporter
+}
+
+func TestSpanContextFromDefaultsToBackground(t *testing.T) {
+	ctx := newGetCtx(t)
+	if SpanContextFrom(ctx) != context.Background() {
+		t.Error("SpanContextFrom should default to context.Background()")
+	}
+}
+
+func TestTracingRunsInnerHandlerAndSetsSpanContext(t *testing.T) {
+	tracer, _ := newRecordingTracer(t)
+
+	var called bool
+	chained := New(Tracing(tracer)).Then(func(ctx *fasthttp.RequestCtx) {
+		called = true
+		if SpanContextFrom(ctx) == context.Background() {
+			t.Error("SpanContextFrom should return the request's span context inside the chain")
+		}
+	})
+
+	chained(newGetCtx(t))
+
+	if !called {
+		t.Error("Tracing did not call the inner handler")
+	}
+}
+
+func TestTracingInjectsTraceparentOnTheResponse(t *testing.T) {
+	tracer, exporter := newRecordingTracer(t)
+
+	chained := New(Tracing(tracer)).Then(func(ctx *fasthttp.RequestCtx) {
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	})
+
+	ctx := newGetCtx(t)
+	chained(ctx)
+
+	traceparent := string(ctx.Response.Header.Peek("traceparent"))
+	if traceparent == "" {
+		t.Fatal("Tracing did not inject a traceparent header onto the response")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	traceID := spans[0].SpanContext.TraceID().String()
+	if !strings.Contains(traceparent, traceID) {
+		t.Errorf("traceparent %q does not carry the span's trace ID %q", traceparent, traceID)
+	}
+}
+
+func TestTracingExtractsAnInboundTraceparent(t *testing.T) {
+	tracer, exporter := newRecordingTracer(t)
+
+	const parentTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	const parentSpanID = "00f067aa0ba902b7"
+	ctx := newGetCtx(t)
+	ctx.Request.Header.Set("traceparent", "00-"+parentTraceID+"-"+parentSpanID+"-01")
+
+	chained := New(Tracing(tracer)).Then(func(ctx *fasthttp.RequestCtx) {})
+	chained(ctx)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if got := spans[0].Parent.TraceID().String(); got != parentTraceID {
+		t.Errorf("span's parent trace ID = %q, want %q (the inbound traceparent's)", got, parentTraceID)
+	}
+	if got := spans[0].SpanContext.TraceID().String(); got != parentTraceID {
+		t.Errorf("span's own trace ID = %q, want %q (inherited from the inbound traceparent)", got, parentTraceID)
+	}
+}
+
+func TestTracingSetsErrorStatusOn5xxResponses(t *testing.T) {
+	tracer, exporter := newRecordingTracer(t)
+
+	chained := New(Tracing(tracer)).Then(func(ctx *fasthttp.RequestCtx) {
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+	})
+	chained(newGetCtx(t))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("got status code %v, want %v", spans[0].Status.Code, codes.Error)
+	}
+}
+
+func TestTracingLeavesStatusUnsetOnSuccess(t *testing.T) {
+	tracer, exporter := newRecordingTracer(t)
+
+	chained := New(Tracing(tracer)).Then(func(ctx *fasthttp.RequestCtx) {
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	})
+	chained(newGetCtx(t))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if spans[0].Status.Code == codes.Error {
+		t.Error("Tracing marked a successful response as an error")
+	}
+}
+
+func TestTracingRecordsAndRepanicsOnPanic(t *testing.T) {
+	tracer, exporter := newRecordingTracer(t)
+
+	chained := New(Tracing(tracer)).Then(func(ctx *fasthttp.RequestCtx) {
+		panic("boom")
+	})
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("Tracing swallowed the panic instead of re-panicking")
+		}
+		if r != "boom" {
+			t.Errorf("got recovered value %v, want %q", r, "boom")
+		}
+
+		spans := exporter.GetSpans()
+		if len(spans) != 1 {
+			t.Fatalf("got %d spans, want 1", len(spans))
+		}
+		if spans[0].Status.Code != codes.Error {
+			t.Errorf("got status code %v, want %v", spans[0].Status.Code, codes.Error)
+		}
+		foundException := false
+		for _, e := range spans[0].Events {
+			if e.Name == "exception" {
+				foundException = true
+			}
+		}
+		if !foundException {
+			t.Error("Tracing did not record the panic as a span error event")
+		}
+	}()
+
+	chained(newGetCtx(t))
+}